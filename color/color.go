@@ -0,0 +1,256 @@
+// Package color implements the small slice of colourimetry needed to compare
+// colours the way human vision actually perceives them: sRGB to linear RGB,
+// linear RGB to CIE XYZ (D65), XYZ to CIELAB, and the CIEDE2000 colour
+// difference formula.
+package color
+
+import "math"
+
+// CubeLevels are the sRGB component values (0-255) the xterm 256-colour
+// cube uses for each of its 6 steps per channel.
+var CubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// CubeComponents decomposes a palette index in the 216-colour cube (16-231)
+// into its [0,5] component coordinates.
+func CubeComponents(c int) (r, g, b int) {
+	return (c - 16) / 36, ((c - 16) % 36) / 6, (c - 16) % 6
+}
+
+// NearestCubeLevel returns the index (0-5) of the cube level closest to v,
+// an 8-bit sRGB component value (0-255).
+func NearestCubeLevel(v float64) int {
+	best, bestDiff := 0, math.Inf(1)
+	for i, lvl := range CubeLevels {
+		if diff := math.Abs(v - float64(lvl)); diff < bestDiff {
+			best, bestDiff = i, diff
+		}
+	}
+	return best
+}
+
+// NearestCubeIndex snaps 8-bit sRGB components to the closest colour in the
+// 216-colour cube.
+func NearestCubeIndex(r, g, b float64) int {
+	return 16 + NearestCubeLevel(r)*36 + NearestCubeLevel(g)*6 + NearestCubeLevel(b)
+}
+
+// SRGBToLinear converts a single gamma-encoded sRGB channel (0-1) to its
+// linear-light equivalent.
+func SRGBToLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// LinearToXYZ converts linear-light sRGB (each channel 0-1) to CIE XYZ using
+// the sRGB/D65 primaries.
+func LinearToXYZ(r, g, b float64) (x, y, z float64) {
+	x = 0.4124564*r + 0.3575761*g + 0.1804375*b
+	y = 0.2126729*r + 0.7151522*g + 0.0721750*b
+	z = 0.0193339*r + 0.1191920*g + 0.9503041*b
+	return x, y, z
+}
+
+// LinearToSRGB converts a single linear-light channel (0-1) to its
+// gamma-encoded sRGB equivalent, the inverse of SRGBToLinear.
+func LinearToSRGB(c float64) float64 {
+	if c <= 0.0031308 {
+		return c * 12.92
+	}
+	return 1.055*math.Pow(c, 1/2.4) - 0.055
+}
+
+// XYZToLinear converts CIE XYZ back to linear-light sRGB, the inverse of
+// LinearToXYZ.
+func XYZToLinear(x, y, z float64) (r, g, b float64) {
+	r = 3.2404542*x - 1.5371385*y - 0.4985314*z
+	g = -0.9692660*x + 1.8760108*y + 0.0415560*z
+	b = 0.0556434*x - 0.2040259*y + 1.0572252*z
+	return r, g, b
+}
+
+// D65 reference white, normalised so Y=1.
+const (
+	whiteX = 0.95047
+	whiteY = 1.00000
+	whiteZ = 1.08883
+)
+
+func labF(t float64) float64 {
+	const delta = 6.0 / 29.0
+	if t > delta*delta*delta {
+		return math.Cbrt(t)
+	}
+	return t/(3*delta*delta) + 4.0/29.0
+}
+
+// XYZToLab converts CIE XYZ to CIELAB using the D65 reference white.
+func XYZToLab(x, y, z float64) (l, a, b float64) {
+	fx := labF(x / whiteX)
+	fy := labF(y / whiteY)
+	fz := labF(z / whiteZ)
+
+	l = 116*fy - 16
+	a = 500 * (fx - fy)
+	b = 200 * (fy - fz)
+
+	return l, a, b
+}
+
+// LabToLCh converts CIELAB to cylindrical CIELCh (lightness, chroma, hue in
+// degrees 0-360).
+func LabToLCh(l, a, b float64) (lOut, c, h float64) {
+	c = math.Hypot(a, b)
+	h = math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return l, c, h
+}
+
+// LabToXYZ converts CIELAB back to CIE XYZ using the D65 reference white,
+// the inverse of XYZToLab.
+func LabToXYZ(l, a, b float64) (x, y, z float64) {
+	fy := (l + 16) / 116
+	fx := fy + a/500
+	fz := fy - b/200
+
+	const delta = 6.0 / 29.0
+	finv := func(t float64) float64 {
+		if t > delta {
+			return t * t * t
+		}
+		return 3 * delta * delta * (t - 4.0/29.0)
+	}
+
+	x = whiteX * finv(fx)
+	y = whiteY * finv(fy)
+	z = whiteZ * finv(fz)
+
+	return x, y, z
+}
+
+// LinearToOklab converts linear-light sRGB to Oklab, per Björn Ottosson's
+// "A perceptual color space for image processing" formulation.
+func LinearToOklab(r, g, b float64) (l, a, bOut float64) {
+	lc := 0.4122214708*r + 0.5363325363*g + 0.0514459929*b
+	mc := 0.2119034982*r + 0.6806995451*g + 0.1073969566*b
+	sc := 0.0883024619*r + 0.2817188376*g + 0.6299787005*b
+
+	lc, mc, sc = math.Cbrt(lc), math.Cbrt(mc), math.Cbrt(sc)
+
+	l = 0.2104542553*lc + 0.7936177850*mc - 0.0040720468*sc
+	a = 1.9779984951*lc - 2.4285922050*mc + 0.4505937099*sc
+	bOut = 0.0259040371*lc + 0.7827717662*mc - 0.8086757660*sc
+
+	return l, a, bOut
+}
+
+// OklabToLinear converts Oklab back to linear-light sRGB, the inverse of
+// LinearToOklab.
+func OklabToLinear(l, a, b float64) (r, g, bOut float64) {
+	lc := l + 0.3963377774*a + 0.2158037573*b
+	mc := l - 0.1055613458*a - 0.0638541728*b
+	sc := l - 0.0894841775*a - 1.2914855480*b
+
+	lc, mc, sc = lc*lc*lc, mc*mc*mc, sc*sc*sc
+
+	r = 4.0767416621*lc - 3.3077115913*mc + 0.2309699292*sc
+	g = -1.2684380046*lc + 2.6097574011*mc - 0.3413193965*sc
+	bOut = -0.0041960863*lc - 0.7034186147*mc + 1.7076147010*sc
+
+	return r, g, bOut
+}
+
+// DeltaE2000 computes the CIEDE2000 colour difference between two CIELAB
+// colours, as defined in Sharma, Wu & Dalal, "The CIEDE2000 Color-Difference
+// Formula: Implementation Notes, Supplementary Test Data, and Mathematical
+// Observations" (2005).
+func DeltaE2000(l1, a1, b1, l2, a2, b2 float64) float64 {
+	const (
+		kL = 1.0
+		kC = 1.0
+		kH = 1.0
+	)
+
+	c1 := math.Hypot(a1, b1)
+	c2 := math.Hypot(a2, b2)
+	cBar := (c1 + c2) / 2
+
+	cBar7 := math.Pow(cBar, 7)
+	g := 0.5 * (1 - math.Sqrt(cBar7/(cBar7+math.Pow(25, 7))))
+
+	a1p := a1 * (1 + g)
+	a2p := a2 * (1 + g)
+
+	c1p := math.Hypot(a1p, b1)
+	c2p := math.Hypot(a2p, b2)
+
+	h1p := hueDeg(a1p, b1)
+	h2p := hueDeg(a2p, b2)
+
+	deltaLp := l2 - l1
+	deltaCp := c2p - c1p
+
+	var deltahp float64
+	switch {
+	case c1p*c2p == 0:
+		deltahp = 0
+	case math.Abs(h2p-h1p) <= 180:
+		deltahp = h2p - h1p
+	case h2p-h1p > 180:
+		deltahp = h2p - h1p - 360
+	default:
+		deltahp = h2p - h1p + 360
+	}
+	deltaHp := 2 * math.Sqrt(c1p*c2p) * math.Sin(deltahp*math.Pi/360)
+
+	lBarp := (l1 + l2) / 2
+	cBarp := (c1p + c2p) / 2
+
+	var hBarp float64
+	switch {
+	case c1p*c2p == 0:
+		hBarp = h1p + h2p
+	case math.Abs(h1p-h2p) <= 180:
+		hBarp = (h1p + h2p) / 2
+	case h1p+h2p < 360:
+		hBarp = (h1p+h2p)/2 + 180
+	default:
+		hBarp = (h1p+h2p)/2 - 180
+	}
+
+	t := 1 - 0.17*math.Cos((hBarp-30)*math.Pi/180) +
+		0.24*math.Cos(2*hBarp*math.Pi/180) +
+		0.32*math.Cos((3*hBarp+6)*math.Pi/180) -
+		0.20*math.Cos((4*hBarp-63)*math.Pi/180)
+
+	deltaTheta := 30 * math.Exp(-math.Pow((hBarp-275)/25, 2))
+
+	cBarp7 := math.Pow(cBarp, 7)
+	rC := 2 * math.Sqrt(cBarp7/(cBarp7+math.Pow(25, 7)))
+	rT := -rC * math.Sin(2*deltaTheta*math.Pi/180)
+
+	sL := 1 + (0.015*math.Pow(lBarp-50, 2))/math.Sqrt(20+math.Pow(lBarp-50, 2))
+	sC := 1 + 0.045*cBarp
+	sH := 1 + 0.015*cBarp*t
+
+	return math.Sqrt(
+		math.Pow(deltaLp/(kL*sL), 2) +
+			math.Pow(deltaCp/(kC*sC), 2) +
+			math.Pow(deltaHp/(kH*sH), 2) +
+			rT*(deltaCp/(kC*sC))*(deltaHp/(kH*sH)),
+	)
+}
+
+func hueDeg(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
@@ -0,0 +1,74 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDeltaE2000 checks DeltaE2000 against a selection of the reference
+// pairs from Sharma, Wu & Dalal's worked-example test data (2005), which
+// the implementation cites.
+func TestDeltaE2000(t *testing.T) {
+	tests := []struct {
+		l1, a1, b1 float64
+		l2, a2, b2 float64
+		want       float64
+	}{
+		{50.0000, 2.6772, -79.7751, 50.0000, 0.0000, -82.7485, 2.0425},
+		{50.0000, 3.1571, -77.2803, 50.0000, 0.0000, -82.7485, 2.8615},
+		{50.0000, -1.3802, -84.2814, 50.0000, 0.0000, -82.7485, 1.0000},
+		{50.0000, 0.0000, 0.0000, 50.0000, -1.0000, 2.0000, 2.3669},
+		{50.0000, 2.4900, -0.0010, 50.0000, -2.4900, 0.0009, 7.1792},
+	}
+
+	const tol = 1e-4
+
+	for _, tt := range tests {
+		got := DeltaE2000(tt.l1, tt.a1, tt.b1, tt.l2, tt.a2, tt.b2)
+		if math.Abs(got-tt.want) > tol {
+			t.Errorf("DeltaE2000(%v,%v,%v, %v,%v,%v) = %.4f, want %.4f",
+				tt.l1, tt.a1, tt.b1, tt.l2, tt.a2, tt.b2, got, tt.want)
+		}
+	}
+}
+
+func TestDeltaE2000Identity(t *testing.T) {
+	if d := DeltaE2000(62.3, 14.2, -7.8, 62.3, 14.2, -7.8); d != 0 {
+		t.Errorf("DeltaE2000 of identical colours = %v, want 0", d)
+	}
+}
+
+// TestOklabRoundTrip checks that OklabToLinear(LinearToOklab(...)) recovers
+// the original linear-sRGB input across the RGB cube, the property the
+// -space=oklab gradient mode relies on when snapping interpolated points
+// back to the nearest cube colour.
+func TestOklabRoundTrip(t *testing.T) {
+	const tol = 1e-6
+
+	for _, r := range []float64{0, 0.2, 0.5, 0.8, 1} {
+		for _, g := range []float64{0, 0.2, 0.5, 0.8, 1} {
+			for _, b := range []float64{0, 0.2, 0.5, 0.8, 1} {
+				l, a, bOut := LinearToOklab(r, g, b)
+				r2, g2, b2 := OklabToLinear(l, a, bOut)
+
+				if math.Abs(r2-r) > tol || math.Abs(g2-g) > tol || math.Abs(b2-b) > tol {
+					t.Errorf("round trip of (%v,%v,%v) = (%v,%v,%v), want back the original", r, g, b, r2, g2, b2)
+				}
+			}
+		}
+	}
+}
+
+// TestLinearToOklabGrey checks that an achromatic input (equal LMS
+// responses) maps to a=b=0, as the Oklab opponent channels require by
+// construction.
+func TestLinearToOklabGrey(t *testing.T) {
+	const tol = 1e-6
+
+	for _, v := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		_, a, b := LinearToOklab(v, v, v)
+		if math.Abs(a) > tol || math.Abs(b) > tol {
+			t.Errorf("LinearToOklab(%v,%v,%v) a,b = %v,%v, want 0,0", v, v, v, a, b)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+// Package gradient interpolates between xterm-256 palette colours in a
+// chosen colour space and snaps the result back to the nearest cube colour,
+// for smooth or hard-edged (banded) swatch gradients.
+package gradient
+
+import (
+	"math"
+
+	"colours/color"
+)
+
+// Space selects the colour space a Gradient interpolates through.
+type Space int
+
+const (
+	RGB Space = iota
+	HSL
+	LabSpace
+	Oklab
+)
+
+// Color is the gradient package's working colour representation:
+// gamma-encoded sRGB, each channel in [0,1].
+type Color struct {
+	R, G, B float64
+}
+
+func indexToColor(c int) Color {
+	r, g, b := color.CubeComponents(c)
+	return Color{
+		float64(color.CubeLevels[r]) / 255,
+		float64(color.CubeLevels[g]) / 255,
+		float64(color.CubeLevels[b]) / 255,
+	}
+}
+
+// Nearest snaps a Color to the closest colour in the 216-colour cube.
+func (c Color) Nearest() int {
+	return color.NearestCubeIndex(c.R*255, c.G*255, c.B*255)
+}
+
+func clamp01(v float64) float64 {
+	switch {
+	case v < 0:
+		return 0
+	case v > 1:
+		return 1
+	default:
+		return v
+	}
+}
+
+func lerp(a, b, t float64) float64 { return a + (b-a)*t }
+
+// Stop is one colour anchor in a Gradient.
+type Stop struct {
+	Index int
+}
+
+// Gradient interpolates between a sequence of palette-index stops, evenly
+// spaced along [0,1], in a chosen colour space.
+type Gradient struct {
+	Stops []Stop
+	Space Space
+
+	// bands is 0 for a smooth gradient, or the band count set by Sharp.
+	bands int
+}
+
+// New builds a Gradient from palette indices, evenly spaced along [0,1].
+func New(stops []int, space Space) *Gradient {
+	s := make([]Stop, len(stops))
+	for i, idx := range stops {
+		s[i] = Stop{Index: idx}
+	}
+	return &Gradient{Stops: s, Space: space}
+}
+
+// At returns the interpolated colour at position t (0-1) along the
+// gradient.
+func (g *Gradient) At(t float64) Color {
+	if len(g.Stops) == 0 {
+		return Color{}
+	}
+
+	if g.bands > 0 {
+		band := int(t * float64(g.bands))
+		if band >= g.bands {
+			band = g.bands - 1
+		}
+		if band < 0 {
+			band = 0
+		}
+		// Sample the band's centre so the whole band renders as one flat colour.
+		t = (float64(band) + 0.5) / float64(g.bands)
+	}
+
+	switch {
+	case len(g.Stops) == 1 || t <= 0:
+		return indexToColor(g.Stops[0].Index)
+	case t >= 1:
+		return indexToColor(g.Stops[len(g.Stops)-1].Index)
+	}
+
+	segments := len(g.Stops) - 1
+	pos := t * float64(segments)
+	i := int(pos)
+	if i >= segments {
+		i = segments - 1
+	}
+
+	from := indexToColor(g.Stops[i].Index)
+	to := indexToColor(g.Stops[i+1].Index)
+
+	return g.interpolate(from, to, pos-float64(i))
+}
+
+func (g *Gradient) interpolate(from, to Color, t float64) Color {
+	switch g.Space {
+	case HSL:
+		return lerpHSL(from, to, t)
+	case LabSpace:
+		return lerpLab(from, to, t)
+	case Oklab:
+		return lerpOklab(from, to, t)
+	default:
+		return Color{lerp(from.R, to.R, t), lerp(from.G, to.G, t), lerp(from.B, to.B, t)}
+	}
+}
+
+// Colors samples the gradient at n evenly spaced points and snaps each to
+// the nearest cube colour.
+func (g *Gradient) Colors(n int) []int {
+	if n <= 0 {
+		return nil
+	}
+
+	result := make([]int, n)
+	for i := range result {
+		t := 0.0
+		if n > 1 {
+			t = float64(i) / float64(n-1)
+		}
+		result[i] = g.At(t).Nearest()
+	}
+
+	return result
+}
+
+// Sharp returns a copy of the gradient quantised into n equal-width bands
+// with hard edges: every t within a band samples that band's centre colour,
+// instead of blending continuously.
+func (g *Gradient) Sharp(n int) *Gradient {
+	return &Gradient{Stops: g.Stops, Space: g.Space, bands: n}
+}
+
+func colorToHSL(c Color) (h, s, l float64) {
+	max := math.Max(c.R, math.Max(c.G, c.B))
+	min := math.Min(c.R, math.Min(c.G, c.B))
+	l = (max + min) / 2
+
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+
+	switch max {
+	case c.R:
+		h = 60 * math.Mod((c.G-c.B)/d, 6)
+	case c.G:
+		h = 60 * ((c.B-c.R)/d + 2)
+	default:
+		h = 60 * ((c.R-c.G)/d + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+
+	return h, s, l
+}
+
+func hslToColor(h, s, l float64) Color {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var rf, gf, bf float64
+	switch {
+	case h < 60:
+		rf, gf, bf = c, x, 0
+	case h < 120:
+		rf, gf, bf = x, c, 0
+	case h < 180:
+		rf, gf, bf = 0, c, x
+	case h < 240:
+		rf, gf, bf = 0, x, c
+	case h < 300:
+		rf, gf, bf = x, 0, c
+	default:
+		rf, gf, bf = c, 0, x
+	}
+
+	return Color{rf + m, gf + m, bf + m}
+}
+
+// lerpHSL interpolates through HSL, taking the shorter arc around the hue
+// circle so e.g. a red-to-red-via-cyan gradient never happens by accident.
+func lerpHSL(from, to Color, t float64) Color {
+	h1, s1, l1 := colorToHSL(from)
+	h2, s2, l2 := colorToHSL(to)
+
+	diff := h2 - h1
+	switch {
+	case diff > 180:
+		diff -= 360
+	case diff < -180:
+		diff += 360
+	}
+
+	h := math.Mod(h1+diff*t+360, 360)
+
+	return hslToColor(h, lerp(s1, s2, t), lerp(l1, l2, t))
+}
+
+func colorToLab(c Color) (l, a, b float64) {
+	x, y, z := color.LinearToXYZ(color.SRGBToLinear(c.R), color.SRGBToLinear(c.G), color.SRGBToLinear(c.B))
+	return color.XYZToLab(x, y, z)
+}
+
+func labToColor(l, a, b float64) Color {
+	x, y, z := color.LabToXYZ(l, a, b)
+	rLin, gLin, bLin := color.XYZToLinear(x, y, z)
+	return Color{
+		clamp01(color.LinearToSRGB(rLin)),
+		clamp01(color.LinearToSRGB(gLin)),
+		clamp01(color.LinearToSRGB(bLin)),
+	}
+}
+
+func lerpLab(from, to Color, t float64) Color {
+	l1, a1, b1 := colorToLab(from)
+	l2, a2, b2 := colorToLab(to)
+	return labToColor(lerp(l1, l2, t), lerp(a1, a2, t), lerp(b1, b2, t))
+}
+
+func colorToOklab(c Color) (l, a, b float64) {
+	return color.LinearToOklab(color.SRGBToLinear(c.R), color.SRGBToLinear(c.G), color.SRGBToLinear(c.B))
+}
+
+func oklabToColor(l, a, b float64) Color {
+	rLin, gLin, bLin := color.OklabToLinear(l, a, b)
+	return Color{
+		clamp01(color.LinearToSRGB(rLin)),
+		clamp01(color.LinearToSRGB(gLin)),
+		clamp01(color.LinearToSRGB(bLin)),
+	}
+}
+
+func lerpOklab(from, to Color, t float64) Color {
+	l1, a1, b1 := colorToOklab(from)
+	l2, a2, b2 := colorToOklab(to)
+	return oklabToColor(lerp(l1, l2, t), lerp(a1, a2, t), lerp(b1, b2, t))
+}
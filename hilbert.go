@@ -0,0 +1,95 @@
+package main
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// hilbertIndex computes the distance along a 3D Hilbert curve for grid
+// coordinates (x, y, z) in [0, 2^order), using Skilling's transpose
+// algorithm (J. Skilling, "Programming the Hilbert Curve", AIP Conf. Proc.
+// 707, 2004): undo the per-level Gray-code rotation/reflection to get the
+// transpose form, then interleave its bits into a single index.
+func hilbertIndex(x, y, z, order int) uint64 {
+	const n = 3
+	bits := uint(order)
+
+	x2 := [n]uint32{uint32(x), uint32(y), uint32(z)}
+
+	// Inverse undo: at each level, rotate/reflect the lower axes according
+	// to the octant the point falls in.
+	for q := uint32(1) << (bits - 1); q > 1; q >>= 1 {
+		p := q - 1
+		for i := 0; i < n; i++ {
+			if x2[i]&q != 0 {
+				x2[0] ^= p
+			} else {
+				t := (x2[0] ^ x2[i]) & p
+				x2[0] ^= t
+				x2[i] ^= t
+			}
+		}
+	}
+
+	// Gray encode.
+	for i := 1; i < n; i++ {
+		x2[i] ^= x2[i-1]
+	}
+	t := uint32(0)
+	for q := uint32(1) << (bits - 1); q > 1; q >>= 1 {
+		if x2[n-1]&q != 0 {
+			t ^= q - 1
+		}
+	}
+	for i := range x2 {
+		x2[i] ^= t
+	}
+
+	// Pack the transposed coordinates into a single index, most significant
+	// bit first, one axis at a time.
+	var index uint64
+	for bit := int(bits) - 1; bit >= 0; bit-- {
+		for i := 0; i < n; i++ {
+			index <<= 1
+			if x2[i]&(1<<uint(bit)) != 0 {
+				index |= 1
+			}
+		}
+	}
+
+	return index
+}
+
+// hilbertOrder is the bit depth used to cover the 6x6x6 cube: 2^3 = 8 spans
+// the 0-5 component range.
+const hilbertOrder = 3
+
+// hilbertScale maps a cube component (0-5) onto the order-3 curve's 0-7
+// lattice.
+func hilbertScale(v int) int {
+	return int(math.Round(float64(v) * 7 / 5))
+}
+
+// HilbertOrder orders the 216-colour cube along a 3D Hilbert curve over the
+// (R,G,B) in [0,5]^3 lattice, so that neighbours in the result lie close
+// together in RGB space. Components are scaled to the curve's 0-7 range
+// (levels 0,1,3,4,6,7), so the curve skips the lattice points between them:
+// most consecutive colours differ by one step in one component, but some
+// differ by one step in each of two components. The scaled levels are
+// distinct and hilbertIndex is a bijection, so no two colours tie.
+func HilbertOrder(colours []int) []int {
+	result := slices.Clone(colours)
+
+	slices.SortStableFunc(result, func(a, b int) int {
+		ar, ag, ab := rgb(a)
+		br, bg, bb := rgb(b)
+
+		aIdx := hilbertIndex(hilbertScale(ar), hilbertScale(ag), hilbertScale(ab), hilbertOrder)
+		bIdx := hilbertIndex(hilbertScale(br), hilbertScale(bg), hilbertScale(bb), hilbertOrder)
+
+		return cmp.Compare(aIdx, bIdx)
+	})
+
+	return result
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"sort"
+	"testing"
+)
+
+// TestHilbertIndexBijection checks that hilbertIndex maps every point of
+// the order-3 lattice (8x8x8) to a distinct index in [0, 512), i.e. it is a
+// bijection rather than just a hash.
+func TestHilbertIndexBijection(t *testing.T) {
+	type point struct{ x, y, z int }
+
+	seen := make(map[uint64]point, 512)
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			for z := 0; z < 8; z++ {
+				idx := hilbertIndex(x, y, z, hilbertOrder)
+				if idx > 511 {
+					t.Fatalf("hilbertIndex(%d,%d,%d) = %d, out of [0,512) range", x, y, z, idx)
+				}
+				if other, ok := seen[idx]; ok {
+					t.Fatalf("hilbertIndex(%d,%d,%d) collides with %v at index %d", x, y, z, other, idx)
+				}
+				seen[idx] = point{x, y, z}
+			}
+		}
+	}
+
+	if len(seen) != 512 {
+		t.Fatalf("got %d distinct indices, want 512", len(seen))
+	}
+}
+
+// TestHilbertIndexAdjacency checks the defining property of a Hilbert
+// curve: walking the lattice in order of increasing index moves to a
+// grid-adjacent point (Manhattan distance 1) at every step.
+func TestHilbertIndexAdjacency(t *testing.T) {
+	type point struct{ x, y, z int }
+
+	points := make([]point, 0, 512)
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			for z := 0; z < 8; z++ {
+				points = append(points, point{x, y, z})
+			}
+		}
+	}
+
+	index := func(p point) uint64 {
+		return hilbertIndex(p.x, p.y, p.z, hilbertOrder)
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return index(points[i]) < index(points[j])
+	})
+
+	abs := func(v int) int {
+		if v < 0 {
+			return -v
+		}
+		return v
+	}
+
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		manhattan := abs(a.x-b.x) + abs(a.y-b.y) + abs(a.z-b.z)
+		if manhattan != 1 {
+			t.Fatalf("step %d->%d: %v to %v is not grid-adjacent (manhattan distance %d)", i-1, i, a, b, manhattan)
+		}
+	}
+}
@@ -5,19 +5,46 @@ import (
 	"flag"
 	"fmt"
 	"math"
+	"math/rand"
 	"os"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"colours/color"
+	"colours/gradient"
+	"colours/palettegen"
 )
 
+// activeMetric selects the colour-distance metric used by -dist and the
+// harmony search in findClosestColour. One of "rgb", "lab", "de2000".
+var activeMetric = "rgb"
+
 func main() {
+	describe := flag.Int("describe", 0, "print the nearest named colours for a palette index (e.g., -describe=21)")
 	distance := flag.Int("dist", 0, "sort colours by distance from specified colour (e.g., -dist=21)")
+	gradSpec := flag.String("grad", "", "gradient between palette indices: -grad=A:B[:N] or -grad=A,B,C:N")
 	greyscale := flag.Bool("grey", false, "sort colours by greyscale value")
 	harmonious := flag.Int("harm", 0, "show harmonious colours for specified colour (e.g., -harm=21)")
+	hilbert := flag.Bool("hilbert", false, "sort colours along a 3D Hilbert curve for coherent swatch layout")
 	hue := flag.Bool("hue", false, "sort colours by hue")
+	labSort := flag.Bool("lab", false, "sort colours by CIELAB lightness")
+	lchSort := flag.Bool("lch", false, "sort colours by CIELCh hue")
 	luminance := flag.Bool("lum", false, "sort colours by brightness")
+	metric := flag.String("metric", "rgb", "distance metric for -dist and harmony search: rgb, lab, de2000")
+	name := flag.String("name", "", "resolve a named colour (e.g., orange, sky blue) and show its harmonies")
+	pair := flag.Bool("pair", false, "print each colour with an automatically chosen, legible foreground label (WCAG contrast)")
+	paletteSpec := flag.String("palette", "", "generate a palette: -palette=warm|happy|soft[,n] (default n=12)")
 	saturation := flag.Bool("sat", false, "sort colours by vibrancy")
+	scheme := flag.String("scheme", "", "show only one harmony scheme for -harm/-name (e.g., -scheme=Analogous)")
+	seed := flag.Int64("seed", 0, "seed for deterministic -palette generation (0 = random)")
+	sharpBands := flag.Int("sharp", 0, "quantise -grad into N equal-width hard-edged bands")
 	similarity := flag.Bool("sim", false, "sort colours by visual similarity")
+	space := flag.String("space", "rgb", "interpolation space for -grad: rgb, hsl, lab, oklab")
+	spread := flag.Float64("spread", 30, "angle in degrees between analogous hues (default 30)")
 	temperature := flag.Bool("temp", false, "sort colours by warm/cool")
+	tolerance := flag.Float64("tol", 15, "hue tolerance in degrees for the harmony search; slots with no match within this window are omitted")
 
 	flag.Parse()
 
@@ -33,6 +60,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Validate describe reference colour if specified
+	if *describe != 0 && (*describe < 16 || *describe > 231) {
+		fmt.Printf("Error: -describe requires a colour number from 16 to 231 (got %d)\n", *describe)
+		os.Exit(1)
+	}
+
+	// Validate and apply the distance metric
+	switch *metric {
+	case "rgb", "lab", "de2000":
+		activeMetric = *metric
+	default:
+		fmt.Printf("Error: -metric must be one of rgb, lab, de2000 (got %q)\n", *metric)
+		os.Exit(1)
+	}
+
+	// Apply the harmony search tuning flags
+	harmonySpread = *spread
+	harmonyTolerance = *tolerance
+	schemeFilter = *scheme
+
 	var (
 		standard = makeRange(0, 7)
 		high     = makeRange(8, 15)
@@ -41,6 +88,10 @@ func main() {
 	)
 
 	switch {
+	case *describe != 0:
+		// Print the nearest named colours for a palette index
+		fmt.Printf("Nearest named colours to %d: %s\n", *describe, strings.Join(Nearest(*describe, 5), ", "))
+		return
 	case *distance != 0:
 		// Distance-based sorting (closest to specified colour first)
 		// Only sort the main colour cube (16-231) by distance
@@ -62,6 +113,27 @@ func main() {
 				cmp.Compare(-aS, -bS),     // More saturated first
 			)
 		})
+	case *gradSpec != "":
+		// Gradient between palette indices, interpolated in the chosen space
+		stops, n, ok := parseGradSpec(*gradSpec)
+		if !ok {
+			fmt.Println("Error: -grad requires at least two colour numbers from 16-231, e.g. -grad=21:196 or -grad=21,82,196:12")
+			os.Exit(1)
+		}
+
+		gradSpace, ok := parseGradSpace(*space)
+		if !ok {
+			fmt.Printf("Error: -space must be one of rgb, hsl, lab, oklab (got %q)\n", *space)
+			os.Exit(1)
+		}
+
+		g := gradient.New(stops, gradSpace)
+		if *sharpBands > 0 {
+			g = g.Sharp(*sharpBands)
+		}
+
+		printPaletteGrid(g.Colors(n))
+		return
 	case *greyscale:
 		// Greyscale-based sorting (lightest to darkest)
 		slices.SortFunc(colour, func(a, b int) int {
@@ -86,6 +158,9 @@ func main() {
 		// Show colour harmonies for specified reference colour
 		printColourHarmonies(*harmonious, colour)
 		return
+	case *hilbert:
+		// Hilbert-curve ordering for spatially coherent swatch layout
+		colour = HilbertOrder(colour)
 	case *hue:
 		// Hue-based sorting (rainbow order)
 		slices.SortFunc(colour, func(a, b int) int {
@@ -111,6 +186,33 @@ func main() {
 				cmp.Compare(-aV, -bV), // Brighter colours first within saturation levels
 			)
 		})
+	case *labSort:
+		// CIELAB-based sorting (perceptually darkest to lightest)
+		slices.SortFunc(colour, func(a, b int) int {
+			aL, _, _ := lab(a)
+			bL, _, _ := lab(b)
+
+			// Secondary sort by hue for better grouping within similar lightness
+			_, _, aH := lch(a)
+			_, _, bH := lch(b)
+
+			return cmp.Or(
+				cmp.Compare(aL, bL),
+				cmp.Compare(aH, bH),
+			)
+		})
+	case *lchSort:
+		// CIELCh-based sorting (perceptual hue order)
+		slices.SortFunc(colour, func(a, b int) int {
+			_, aC, aH := lch(a)
+			_, bC, bH := lch(b)
+
+			// Secondary sort by chroma, more vivid first
+			return cmp.Or(
+				cmp.Compare(aH, bH),
+				cmp.Compare(-aC, -bC),
+			)
+		})
 	case *luminance:
 		// Luminance-based sorting (darkest to lightest)
 		slices.SortFunc(colour, func(a, b int) int {
@@ -131,6 +233,39 @@ func main() {
 				cmp.Compare(-aS, -bS),
 			)
 		})
+	case *name != "":
+		// Resolve a colour name and show its harmonies, as with -harm
+		idx, ok := Lookup(*name)
+		if !ok {
+			fmt.Printf("Error: unknown colour name %q\n", *name)
+			os.Exit(1)
+		}
+		printColourHarmonies(idx, colour)
+		return
+	case *pair:
+		// Print every colour with an automatically chosen legible foreground
+		printColourPairs(append(colour, grey...))
+		return
+	case *paletteSpec != "":
+		// Generate a mutually-distinct palette and render it as a grid
+		kind, n := parsePaletteSpec(*paletteSpec)
+		rng := paletteRNG(*seed)
+
+		var result []int
+		switch kind {
+		case "warm":
+			result = palettegen.FastWarm(n, rng)
+		case "happy":
+			result = palettegen.FastHappy(n, rng)
+		case "soft":
+			result = palettegen.Soft(n, rng, nil)
+		default:
+			fmt.Printf("Error: -palette kind must be one of warm, happy, soft (got %q)\n", kind)
+			os.Exit(1)
+		}
+
+		printPaletteGrid(result)
+		return
 	case *saturation:
 		// Saturation-based sorting (muted to vivid)
 		slices.SortFunc(colour, func(a, b int) int {
@@ -239,15 +374,46 @@ func main() {
 }
 
 func colourDistance(c1, c2 int) float64 {
-	r1, g1, b1 := rgb(c1)
-	r2, g2, b2 := rgb(c2)
+	switch activeMetric {
+	case "lab":
+		l1, a1, b1 := lab(c1)
+		l2, a2, b2 := lab(c2)
+		dl, da, db := l1-l2, a1-a2, b1-b2
+		return math.Sqrt(dl*dl + da*da + db*db)
+	case "de2000":
+		l1, a1, b1 := lab(c1)
+		l2, a2, b2 := lab(c2)
+		return color.DeltaE2000(l1, a1, b1, l2, a2, b2)
+	default:
+		r1, g1, b1 := rgb(c1)
+		r2, g2, b2 := rgb(c2)
 
-	// Euclidean distance in RGB space
-	dr := float64(r1 - r2)
-	dg := float64(g1 - g2)
-	db := float64(b1 - b2)
+		// Euclidean distance in RGB space
+		dr := float64(r1 - r2)
+		dg := float64(g1 - g2)
+		db := float64(b1 - b2)
 
-	return math.Sqrt(dr*dr + dg*dg + db*db)
+		return math.Sqrt(dr*dr + dg*dg + db*db)
+	}
+}
+
+// lab converts a palette index to CIELAB, via sRGB -> linear RGB -> CIE XYZ.
+func lab(c int) (l, a, b float64) {
+	r, g, bl := rgb(c)
+
+	rLin := color.SRGBToLinear(float64(color.CubeLevels[r]) / 255)
+	gLin := color.SRGBToLinear(float64(color.CubeLevels[g]) / 255)
+	bLin := color.SRGBToLinear(float64(color.CubeLevels[bl]) / 255)
+
+	x, y, z := color.LinearToXYZ(rLin, gLin, bLin)
+
+	return color.XYZToLab(x, y, z)
+}
+
+// lch converts a palette index to cylindrical CIELCh.
+func lch(c int) (l, ch, h float64) {
+	l, a, b := lab(c)
+	return color.LabToLCh(l, a, b)
 }
 
 func colourGreyscale(c int) float64 {
@@ -341,10 +507,18 @@ func colourTemperature(c int) float64 {
 	}
 }
 
-// findClosestColour finds the colour closest to the target hue.
+// harmonyTolerance is the default acceptance window (in degrees) findClosestColour
+// searches within; set via -tol.
+var harmonyTolerance float64 = 15
+
+// findClosestColour finds the colour closest to the target hue, within
+// harmonyTolerance degrees. It returns -1 if the cube has no candidate that
+// close, so the scheme omits that slot rather than substituting a distant
+// hue.
 func findClosestColour(targetHue float64, colours []int, referenceColour int) int {
 	var bestColour int = -1
 	var bestDiff float64 = 360
+	var bestDist float64 = math.MaxFloat64
 
 	for _, colour := range colours {
 		if colour == referenceColour {
@@ -360,8 +534,16 @@ func findClosestColour(targetHue float64, colours []int, referenceColour int) in
 
 		// Calculate circular distance between hues
 		diff := math.Min(math.Abs(h-targetHue), 360-math.Abs(h-targetHue))
-		if diff < bestDiff {
+		if diff > harmonyTolerance {
+			continue
+		}
+
+		// Among equally close hues, prefer the one perceptually closer to the
+		// reference colour under the active metric
+		dist := colourDistance(colour, referenceColour)
+		if diff < bestDiff || (diff == bestDiff && dist < bestDist) {
 			bestDiff = diff
+			bestDist = dist
 			bestColour = colour
 		}
 	}
@@ -505,6 +687,50 @@ func generateRGBGradient(referenceColour int, colours []int, numColours int) []i
 	return result
 }
 
+// harmonySpread is the angle (in degrees) generateAnalogous steps by,
+// configurable via -spread.
+var harmonySpread float64 = 30
+
+// generateAnalogous generates base ± spread and base ± 2*spread: the hues
+// either side of the reference colour.
+func generateAnalogous(referenceColour int, colours []int, spread float64) []int {
+	if referenceColour < 16 || referenceColour > 231 {
+		return []int{referenceColour}
+	}
+
+	refH, _, _ := hsv(referenceColour)
+	result := []int{referenceColour}
+
+	for _, mult := range []float64{1, -1, 2, -2} {
+		targetHue := math.Mod(refH+spread*mult+360, 360)
+		if closest := findClosestColour(targetHue, colours, referenceColour); closest != -1 {
+			result = append(result, closest)
+		}
+	}
+
+	return result
+}
+
+// generateRectangle generates the tetradic-offset (rectangle) scheme: base,
+// base+60°, base+180°, base+240°.
+func generateRectangle(referenceColour int, colours []int) []int {
+	if referenceColour < 16 || referenceColour > 231 {
+		return []int{referenceColour}
+	}
+
+	refH, _, _ := hsv(referenceColour)
+	result := []int{referenceColour}
+
+	for _, offset := range []float64{60, 180, 240} {
+		targetHue := math.Mod(refH+offset, 360)
+		if closest := findClosestColour(targetHue, colours, referenceColour); closest != -1 {
+			result = append(result, closest)
+		}
+	}
+
+	return result
+}
+
 // generateSplitComplementary generates base colour + 2 colours adjacent to its
 // complement.
 func generateSplitComplementary(referenceColour int, colours []int, numColours int) []int {
@@ -531,14 +757,15 @@ func generateSplitComplementary(referenceColour int, colours []int, numColours i
 	return result
 }
 
-
 func findHarmoniousColours(referenceColour int, colours []int) map[string][]int {
 	harmony := make(map[string][]int)
 
 	// Generate each harmony scheme using the DRY approach
 	harmony["Complementary"] = generateHarmonyScheme(referenceColour, colours, 2)
+	harmony["Analogous"] = generateAnalogous(referenceColour, colours, harmonySpread)
 	harmony["Triadic"] = generateHarmonyScheme(referenceColour, colours, 3)
-	harmony["Tetradic"] = generateHarmonyScheme(referenceColour, colours, 4)
+	harmony["Square"] = generateHarmonyScheme(referenceColour, colours, 4)
+	harmony["Rectangle"] = generateRectangle(referenceColour, colours)
 	harmony["Pentadic"] = generateHarmonyScheme(referenceColour, colours, 5)
 	harmony["Hexadic"] = generateHarmonyScheme(referenceColour, colours, 6)
 	harmony["Split-complementary"] = generateSplitComplementary(referenceColour, colours, 3)
@@ -557,9 +784,13 @@ func makeRange(from, to int) []int {
 }
 
 func rgb(c int) (r, g, b int) {
-	return (c - 16) / 36, ((c - 16) % 36) / 6, (c - 16) % 6
+	return color.CubeComponents(c)
 }
 
+// schemeFilter restricts printColourHarmonies to a single named scheme when
+// set via -scheme; empty means show all of them.
+var schemeFilter string
+
 func printColourHarmonies(referenceColour int, colours []int) {
 	// Get harmonious colours for reference
 	harmonies := findHarmoniousColours(referenceColour, colours)
@@ -569,9 +800,11 @@ func printColourHarmonies(referenceColour int, colours []int) {
 	// Display each harmony type
 	harmonyOrder := []string{
 		"Complementary",
+		"Analogous",
 		"Split-complementary",
 		"Triadic",
-		"Tetradic",
+		"Square",
+		"Rectangle",
 		"Pentadic",
 		"Hexadic",
 		"Monochrome sequential",
@@ -579,6 +812,10 @@ func printColourHarmonies(referenceColour int, colours []int) {
 	}
 
 	for _, harmonyType := range harmonyOrder {
+		if schemeFilter != "" && !strings.EqualFold(schemeFilter, harmonyType) {
+			continue
+		}
+
 		colours := harmonies[harmonyType]
 		if len(colours) <= 1 {
 			continue // Skip if no harmonious colours found
@@ -604,3 +841,275 @@ func printColour(c int, fg bool) {
 		fmt.Printf("\x1b[48;5;%[1]dm  %03[1]d  \x1b[0m", c)
 	}
 }
+
+// standardRGB holds the approximate sRGB values of the 16 standard and
+// high-intensity ANSI colours (0-15), following the common xterm default
+// palette.
+var standardRGB = [16][3]int{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+	{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// ansi256ToRGB255 converts any xterm-256 index (0-255) to 8-bit sRGB.
+func ansi256ToRGB255(c int) (r, g, b int) {
+	switch {
+	case c < 16:
+		v := standardRGB[c]
+		return v[0], v[1], v[2]
+	case c <= 231:
+		cr, cg, cb := rgb(c)
+		return color.CubeLevels[cr], color.CubeLevels[cg], color.CubeLevels[cb]
+	default:
+		v := 8 + 10*(c-232)
+		return v, v, v
+	}
+}
+
+// relativeLuminance computes the WCAG 2.x relative luminance of a palette
+// colour from its sRGB components.
+func relativeLuminance(c int) float64 {
+	r, g, b := ansi256ToRGB255(c)
+
+	lin := func(v int) float64 {
+		s := float64(v) / 255
+		if s <= 0.03928 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*lin(r) + 0.7152*lin(g) + 0.0722*lin(b)
+}
+
+// contrastRatio computes the WCAG 2.x contrast ratio between two palette
+// colours: (L1+0.05)/(L2+0.05), with L1 the lighter of the two.
+func contrastRatio(c1, c2 int) float64 {
+	l1, l2 := relativeLuminance(c1), relativeLuminance(c2)
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// hsl converts 8-bit sRGB to hue (0-360), saturation and lightness (0-1).
+func hsl(r, g, b int) (h, s, l float64) {
+	rf, gf, bf := float64(r)/255, float64(g)/255, float64(b)/255
+	max := math.Max(rf, math.Max(gf, bf))
+	min := math.Min(rf, math.Min(gf, bf))
+	l = (max + min) / 2
+
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+
+	switch max {
+	case rf:
+		h = 60 * math.Mod((gf-bf)/d, 6)
+	case gf:
+		h = 60 * ((bf-rf)/d + 2)
+	default:
+		h = 60 * ((rf-gf)/d + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+
+	return h, s, l
+}
+
+// hslToRGB255 converts hue (0-360), saturation and lightness (0-1) back to
+// 8-bit sRGB.
+func hslToRGB255(h, s, l float64) (r, g, b int) {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var rf, gf, bf float64
+	switch {
+	case h < 60:
+		rf, gf, bf = c, x, 0
+	case h < 120:
+		rf, gf, bf = x, c, 0
+	case h < 180:
+		rf, gf, bf = 0, c, x
+	case h < 240:
+		rf, gf, bf = 0, x, c
+	case h < 300:
+		rf, gf, bf = x, 0, c
+	default:
+		rf, gf, bf = c, 0, x
+	}
+
+	return int(math.Round((rf + m) * 255)), int(math.Round((gf + m) * 255)), int(math.Round((bf + m) * 255))
+}
+
+// bgCandidateLightness are the lightness values tried when generating
+// foreground candidates for BestForeground, from near-black to near-white.
+var bgCandidateLightness = []float64{0.04, 0.12, 0.22, 0.32, 0.68, 0.78, 0.88, 0.96}
+
+// BestForeground picks the most legible foreground colour from the
+// 256-colour palette for the given background, using WCAG 2.x contrast
+// ratio. Candidates keep the background's HSL hue, take each lightness in
+// bgCandidateLightness with either the background's own saturation or full
+// saturation, and are snapped to the nearest cube colour; the one with the
+// highest contrast against bg wins, as long as it
+// clears a 4.5:1 target. If nothing does, falls back to plain black (16) or
+// white (231), whichever contrasts more.
+func BestForeground(bg int) int {
+	r, g, b := ansi256ToRGB255(bg)
+	h, s, _ := hsl(r, g, b)
+
+	best, bestRatio := -1, 0.0
+
+	for _, l := range bgCandidateLightness {
+		for _, cs := range []float64{s, 1.0} {
+			cr, cg, cb := hslToRGB255(h, cs, l)
+			candidate := color.NearestCubeIndex(float64(cr), float64(cg), float64(cb))
+
+			if ratio := contrastRatio(bg, candidate); ratio > bestRatio {
+				best, bestRatio = candidate, ratio
+			}
+		}
+	}
+
+	if best == -1 || bestRatio < 4.5 {
+		if contrastRatio(bg, 16) >= contrastRatio(bg, 231) {
+			return 16
+		}
+		return 231
+	}
+
+	return best
+}
+
+// printColourPair prints bg as a swatch with its index rendered as a label
+// in the most legible foreground colour BestForeground can find.
+func printColourPair(bg int) {
+	fg := BestForeground(bg)
+	fmt.Printf("\x1b[48;5;%dm\x1b[38;5;%dm %03d \x1b[0m", bg, fg, bg)
+}
+
+// printColourPairs prints a grid of colour/foreground pairs, six per row.
+func printColourPairs(colours []int) {
+	for block := range slices.Chunk(colours, 6) {
+		for _, c := range block {
+			printColourPair(c)
+		}
+		fmt.Println()
+	}
+}
+
+// parsePaletteSpec parses a -palette flag value of the form "kind" or
+// "kind,n", defaulting n to 12 when omitted or invalid.
+func parsePaletteSpec(spec string) (kind string, n int) {
+	n = 12
+
+	parts := strings.SplitN(spec, ",", 2)
+	kind = parts[0]
+
+	if len(parts) == 2 {
+		if v, err := strconv.Atoi(parts[1]); err == nil && v > 0 {
+			n = v
+		}
+	}
+
+	return kind, n
+}
+
+// parseGradSpec parses a -grad flag value: either two palette indices
+// separated by ':', or two or more separated by ',', in both cases with an
+// optional trailing step count after a final ':' (default 10). For example
+// "21:196", "21:196:12" and "21,82,196:12" are all valid; in "21:82:196" the
+// last number is taken as the step count.
+func parseGradSpec(spec string) (stops []int, n int, ok bool) {
+	n = 10
+
+	var parts []string
+
+	if strings.Contains(spec, ",") {
+		// Multi-stop form "A,B,C[:N]": stops are comma-separated, with an
+		// optional trailing ":N" step count.
+		rest := spec
+		if i := strings.LastIndex(spec, ":"); i != -1 {
+			if v, err := strconv.Atoi(spec[i+1:]); err == nil && v > 0 {
+				n = v
+				rest = spec[:i]
+			}
+		}
+		parts = strings.Split(rest, ",")
+	} else {
+		// Two-stop form "A:B", or "A:B:N" with an explicit step count.
+		parts = strings.Split(spec, ":")
+		if len(parts) >= 3 {
+			if v, err := strconv.Atoi(parts[len(parts)-1]); err == nil && v > 0 {
+				n = v
+				parts = parts[:len(parts)-1]
+			}
+		}
+	}
+
+	for _, tok := range parts {
+		tok = strings.TrimSpace(tok)
+		if tok == "" {
+			continue
+		}
+		idx, err := strconv.Atoi(tok)
+		if err != nil || idx < 16 || idx > 231 {
+			return nil, 0, false
+		}
+		stops = append(stops, idx)
+	}
+
+	if len(stops) < 2 {
+		return nil, 0, false
+	}
+
+	return stops, n, true
+}
+
+// parseGradSpace maps a -space flag value to a gradient.Space.
+func parseGradSpace(s string) (gradient.Space, bool) {
+	switch s {
+	case "rgb":
+		return gradient.RGB, true
+	case "hsl":
+		return gradient.HSL, true
+	case "lab":
+		return gradient.LabSpace, true
+	case "oklab":
+		return gradient.Oklab, true
+	default:
+		return gradient.RGB, false
+	}
+}
+
+// paletteRNG returns a seeded random source for -palette generation. A seed
+// of 0 means "unseeded": draw fresh entropy from the clock.
+func paletteRNG(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// printPaletteGrid renders a generated palette through the existing
+// printColour swatches, six colours per row.
+func printPaletteGrid(colours []int) {
+	for row := range slices.Chunk(colours, 6) {
+		for _, t := range []bool{false, true} {
+			for _, c := range row {
+				printColour(c, t)
+			}
+			fmt.Println()
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseGradSpec(t *testing.T) {
+	tests := []struct {
+		spec      string
+		wantStops []int
+		wantN     int
+		wantOK    bool
+	}{
+		{"21:196", []int{21, 196}, 10, true},
+		{"21:196:12", []int{21, 196}, 12, true},
+		{"21,82,196", []int{21, 82, 196}, 10, true},
+		{"21,82,196:12", []int{21, 82, 196}, 12, true},
+		{"21", nil, 0, false},
+		{"", nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		stops, n, ok := parseGradSpec(tt.spec)
+		if ok != tt.wantOK {
+			t.Errorf("parseGradSpec(%q) ok = %v, want %v", tt.spec, ok, tt.wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !slices.Equal(stops, tt.wantStops) {
+			t.Errorf("parseGradSpec(%q) stops = %v, want %v", tt.spec, stops, tt.wantStops)
+		}
+		if n != tt.wantN {
+			t.Errorf("parseGradSpec(%q) n = %d, want %d", tt.spec, n, tt.wantN)
+		}
+	}
+}
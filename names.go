@@ -0,0 +1,150 @@
+package main
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"colours/color"
+)
+
+// namedColour is a curated named colour anchored at a point in HSL space, in
+// the spirit of the CSS/X11 and Xerox NamedColors traditions, used to
+// resolve human colour names to palette indices.
+type namedColour struct {
+	Name    string
+	H, S, L float64
+}
+
+// names is the curated name table. Anchors are approximate HSL points
+// rather than exact RGB triples so they can be resolved against any
+// palette, not just this one.
+var names = []namedColour{
+	{"red", 0, 1.00, 0.50},
+	{"crimson", 348, 0.83, 0.47},
+	{"scarlet", 9, 0.92, 0.55},
+	{"orange", 39, 1.00, 0.50},
+	{"amber", 45, 1.00, 0.50},
+	{"gold", 51, 1.00, 0.50},
+	{"yellow", 60, 1.00, 0.50},
+	{"chartreuse", 90, 1.00, 0.50},
+	{"lime", 120, 1.00, 0.50},
+	{"green", 120, 1.00, 0.25},
+	{"emerald", 140, 0.60, 0.40},
+	{"teal", 180, 1.00, 0.25},
+	{"cyan", 180, 1.00, 0.50},
+	{"turquoise", 174, 0.72, 0.56},
+	{"sky blue", 197, 0.71, 0.73},
+	{"azure", 210, 1.00, 0.50},
+	{"blue", 240, 1.00, 0.50},
+	{"navy", 240, 1.00, 0.25},
+	{"indigo", 255, 0.60, 0.40},
+	{"violet", 270, 0.76, 0.72},
+	{"purple", 270, 1.00, 0.50},
+	{"magenta", 300, 1.00, 0.50},
+	{"pink", 330, 1.00, 0.80},
+	{"rose", 330, 1.00, 0.60},
+	{"maroon", 0, 1.00, 0.25},
+	{"brown", 20, 0.60, 0.30},
+	{"chocolate", 25, 0.75, 0.47},
+	{"tan", 34, 0.44, 0.69},
+	{"beige", 60, 0.56, 0.91},
+	{"olive", 60, 1.00, 0.25},
+	{"khaki", 54, 0.77, 0.75},
+	{"black", 0, 0.00, 0.00},
+	{"grey", 0, 0.00, 0.50},
+	{"gray", 0, 0.00, 0.50},
+	{"silver", 0, 0.00, 0.75},
+	{"white", 0, 0.00, 1.00},
+}
+
+// normaliseColourName lowercases a name and strips spaces and hyphens so
+// "Sky-Blue", "sky blue" and "skyblue" all match the same entry.
+func normaliseColourName(s string) string {
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, " ", "")
+	s = strings.ReplaceAll(s, "-", "")
+	return s
+}
+
+// namedColourRGB returns the 8-bit sRGB anchor point of a named colour.
+func namedColourRGB(n namedColour) (r, g, b int) {
+	return hslToRGB255(n.H, n.S, n.L)
+}
+
+// Lookup resolves a colour name to the closest colour in the 216-colour
+// cube, ignoring case, spaces and hyphens. It reports false if the name is
+// not in the table.
+func Lookup(name string) (int, bool) {
+	target := normaliseColourName(name)
+
+	for _, n := range names {
+		if normaliseColourName(n.Name) == target {
+			r, g, b := namedColourRGB(n)
+			return nearestCubeIndexByDeltaE2000(r, g, b), true
+		}
+	}
+
+	return 0, false
+}
+
+// nearestCubeIndexByDeltaE2000 returns the 216-colour cube index whose
+// CIELAB value is perceptually closest, under CIEDE2000, to the given 8-bit
+// sRGB anchor. This is the same metric Nearest uses for the reverse lookup.
+// Several names can resolve to the same index (e.g. "grey" and "gray"), so
+// Nearest on a resolved index does not always return the name looked up.
+func nearestCubeIndexByDeltaE2000(r, g, b int) int {
+	l1, a1, b1 := rgbToLab(r, g, b)
+
+	best, bestDist := 16, math.Inf(1)
+	for idx := 16; idx <= 231; idx++ {
+		l2, a2, b2 := lab(idx)
+		if dist := color.DeltaE2000(l1, a1, b1, l2, a2, b2); dist < bestDist {
+			best, bestDist = idx, dist
+		}
+	}
+
+	return best
+}
+
+// rgbToLab converts 8-bit sRGB components to CIELAB.
+func rgbToLab(r, g, b int) (l, a, bOut float64) {
+	rLin := color.SRGBToLinear(float64(r) / 255)
+	gLin := color.SRGBToLinear(float64(g) / 255)
+	bLin := color.SRGBToLinear(float64(b) / 255)
+
+	x, y, z := color.LinearToXYZ(rLin, gLin, bLin)
+	return color.XYZToLab(x, y, z)
+}
+
+// Nearest returns the k named colours whose anchor is perceptually closest
+// to palette index idx, nearest first.
+func Nearest(idx int, k int) []string {
+	type scored struct {
+		name string
+		dist float64
+	}
+
+	l1, a1, b1 := lab(idx)
+
+	scores := make([]scored, len(names))
+	for i, n := range names {
+		r, g, b := namedColourRGB(n)
+		l2, a2, b2 := rgbToLab(r, g, b)
+		scores[i] = scored{n.Name, color.DeltaE2000(l1, a1, b1, l2, a2, b2)}
+	}
+
+	slices.SortFunc(scores, func(a, b scored) int { return cmp.Compare(a.dist, b.dist) })
+
+	if k > len(scores) {
+		k = len(scores)
+	}
+
+	result := make([]string, k)
+	for i := range result {
+		result[i] = scores[i].name
+	}
+
+	return result
+}
@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantOK bool
+	}{
+		{"Sky-Blue", true},
+		{"sky blue", true},
+		{"skyblue", true},
+		{"ORANGE", true},
+		{"not-a-colour", false},
+	}
+
+	for _, tt := range tests {
+		idx, ok := Lookup(tt.name)
+		if ok != tt.wantOK {
+			t.Errorf("Lookup(%q) ok = %v, want %v", tt.name, ok, tt.wantOK)
+			continue
+		}
+		if ok && (idx < 16 || idx > 231) {
+			t.Errorf("Lookup(%q) = %d, want an index in the 216-colour cube", tt.name, idx)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	got := Nearest(21, 3)
+
+	if len(got) != 3 {
+		t.Fatalf("Nearest(21, 3) returned %d names, want 3", len(got))
+	}
+
+	for i := 1; i < len(got); i++ {
+		if got[i] == got[i-1] {
+			t.Errorf("Nearest(21, 3) = %v, contains duplicate %q", got, got[i])
+		}
+	}
+}
@@ -0,0 +1,338 @@
+// Package palettegen generates sets of mutually-distinct colours from the
+// xterm 216-colour cube: fast restricted-HSV samplers for "warm" and "happy"
+// moods, and a slower k-means-in-Lab "soft palette" generator for evenly
+// spread, perceptually distinct sets.
+package palettegen
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"colours/color"
+)
+
+// allCube lists every palette index in the 216-colour cube (16-231).
+func allCube() []int {
+	s := make([]int, 216)
+	for i := range s {
+		s[i] = 16 + i
+	}
+	return s
+}
+
+// indexToLab converts a cube index to CIELAB.
+func indexToLab(c int) (l, a, b float64) {
+	r, g, bl := color.CubeComponents(c)
+
+	rLin := color.SRGBToLinear(float64(color.CubeLevels[r]) / 255)
+	gLin := color.SRGBToLinear(float64(color.CubeLevels[g]) / 255)
+	bLin := color.SRGBToLinear(float64(color.CubeLevels[bl]) / 255)
+
+	x, y, z := color.LinearToXYZ(rLin, gLin, bLin)
+
+	return color.XYZToLab(x, y, z)
+}
+
+// hsvToCubeIndex converts HSV (h in [0,360), s and v in [0,1]) to the
+// nearest cube index.
+func hsvToCubeIndex(h, s, v float64) int {
+	c := v * s
+	x := c * (1 - abs(mod(h/60, 2)-1))
+	m := v - c
+
+	var rf, gf, bf float64
+	switch {
+	case h < 60:
+		rf, gf, bf = c, x, 0
+	case h < 120:
+		rf, gf, bf = x, c, 0
+	case h < 180:
+		rf, gf, bf = 0, c, x
+	case h < 240:
+		rf, gf, bf = 0, x, c
+	case h < 300:
+		rf, gf, bf = x, 0, c
+	default:
+		rf, gf, bf = c, 0, x
+	}
+
+	return color.NearestCubeIndex((rf+m)*255, (gf+m)*255, (bf+m)*255)
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func mod(a, b float64) float64 {
+	m := a
+	for m >= b {
+		m -= b
+	}
+	for m < 0 {
+		m += b
+	}
+	return m
+}
+
+// sampleDistinct draws candidates via sample until it has collected n
+// distinct cube indices, or gives up after a generous number of attempts
+// (the cube only has 216 colours, so a restricted HSV region can run out).
+// If random sampling comes up short, the result is backfilled with the
+// unused cube colours whose actual saturation/value lie closest to the
+// [sLo,sHi]x[vLo,vHi] box, so callers always get exactly min(n, 216)
+// colours instead of silently fewer.
+func sampleDistinct(n int, rng *rand.Rand, sLo, sHi, vLo, vHi float64, sample func(rng *rand.Rand) int) []int {
+	if n > 216 {
+		n = 216
+	}
+
+	seen := make(map[int]bool, n)
+	result := make([]int, 0, n)
+
+	for attempts := 0; len(result) < n && attempts < n*200; attempts++ {
+		idx := sample(rng)
+		if seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		result = append(result, idx)
+	}
+
+	if len(result) < n {
+		backfill := allCube()
+		boxDist := func(idx int) float64 {
+			_, s, v := cubeHSV(idx)
+			return outsideBox(s, sLo, sHi) + outsideBox(v, vLo, vHi)
+		}
+		sort.Slice(backfill, func(i, j int) bool {
+			return boxDist(backfill[i]) < boxDist(backfill[j])
+		})
+		for _, idx := range backfill {
+			if len(result) == n {
+				break
+			}
+			if seen[idx] {
+				continue
+			}
+			seen[idx] = true
+			result = append(result, idx)
+		}
+	}
+
+	return result
+}
+
+// outsideBox returns how far v lies outside [lo,hi], or 0 if it is inside.
+func outsideBox(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo - v
+	}
+	if v > hi {
+		return v - hi
+	}
+	return 0
+}
+
+// cubeHSV converts a cube index to HSV.
+func cubeHSV(idx int) (h, s, v float64) {
+	r, g, b := color.CubeComponents(idx)
+	rf := float64(color.CubeLevels[r]) / 255
+	gf := float64(color.CubeLevels[g]) / 255
+	bf := float64(color.CubeLevels[b]) / 255
+
+	maxc := math.Max(rf, math.Max(gf, bf))
+	minc := math.Min(rf, math.Min(gf, bf))
+	v = maxc
+
+	delta := maxc - minc
+	if maxc == 0 {
+		return 0, 0, v
+	}
+	s = delta / maxc
+
+	switch maxc {
+	case rf:
+		h = 60 * mod((gf-bf)/delta, 6)
+	case gf:
+		h = 60 * ((bf-rf)/delta + 2)
+	default:
+		h = 60 * ((rf-gf)/delta + 4)
+	}
+
+	return h, s, v
+}
+
+// FastWarm samples n mutually-distinct "warm" colours: muted, mid-toned
+// hues with saturation in [0.5,0.8] and value in [0.3,0.6], each snapped to
+// the nearest cube colour.
+func FastWarm(n int, rng *rand.Rand) []int {
+	const sLo, sHi, vLo, vHi = 0.5, 0.8, 0.3, 0.6
+	return sampleDistinct(n, rng, sLo, sHi, vLo, vHi, func(rng *rand.Rand) int {
+		h := rng.Float64() * 360
+		s := sLo + rng.Float64()*(sHi-sLo)
+		v := vLo + rng.Float64()*(vHi-vLo)
+		return hsvToCubeIndex(h, s, v)
+	})
+}
+
+// FastHappy samples n mutually-distinct "happy" colours: vivid, bright hues
+// with saturation in [0.7,1.0] and value in [0.65,1.0], each snapped to the
+// nearest cube colour.
+func FastHappy(n int, rng *rand.Rand) []int {
+	const sLo, sHi, vLo, vHi = 0.7, 1.0, 0.65, 1.0
+	return sampleDistinct(n, rng, sLo, sHi, vLo, vHi, func(rng *rand.Rand) int {
+		h := rng.Float64() * 360
+		s := sLo + rng.Float64()*(sHi-sLo)
+		v := vLo + rng.Float64()*(vHi-vLo)
+		return hsvToCubeIndex(h, s, v)
+	})
+}
+
+// Soft generates n evenly spread, perceptually distinct colours by running
+// k-means in Lab space over the 216-colour cube: seed n random Lab points,
+// assign every cube colour to its nearest seed, recompute centroids, and
+// repeat until assignments stop changing or 50 rounds have run. accept, if
+// non-nil, rejects
+// candidate cube colours that fail it (e.g. a minimum-lightness rule) before
+// clustering begins.
+func Soft(n int, rng *rand.Rand, accept func(idx int) bool) []int {
+	candidates := allCube()
+	if accept != nil {
+		filtered := candidates[:0:0]
+		for _, idx := range candidates {
+			if accept(idx) {
+				filtered = append(filtered, idx)
+			}
+		}
+		candidates = filtered
+	}
+
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	// Seed centroids from n random distinct candidates' Lab values.
+	perm := rng.Perm(len(candidates))
+	centroids := make([][3]float64, n)
+	for i := 0; i < n; i++ {
+		l, a, b := indexToLab(candidates[perm[i]])
+		centroids[i] = [3]float64{l, a, b}
+	}
+
+	assignments := make([]int, len(candidates))
+	labs := make([][3]float64, len(candidates))
+	for i, idx := range candidates {
+		l, a, b := indexToLab(idx)
+		labs[i] = [3]float64{l, a, b}
+	}
+
+	for iter := 0; iter < 50; iter++ {
+		changed := false
+
+		for i, lab := range labs {
+			best, bestDist := 0, labDistSq(lab, centroids[0])
+			for k := 1; k < n; k++ {
+				if d := labDistSq(lab, centroids[k]); d < bestDist {
+					best, bestDist = k, d
+				}
+			}
+			if assignments[i] != best {
+				assignments[i] = best
+				changed = true
+			}
+		}
+
+		sums := make([][3]float64, n)
+		counts := make([]int, n)
+		for i, lab := range labs {
+			k := assignments[i]
+			sums[k][0] += lab[0]
+			sums[k][1] += lab[1]
+			sums[k][2] += lab[2]
+			counts[k]++
+		}
+		for k := range centroids {
+			if counts[k] == 0 {
+				continue
+			}
+			centroids[k] = [3]float64{
+				sums[k][0] / float64(counts[k]),
+				sums[k][1] / float64(counts[k]),
+				sums[k][2] / float64(counts[k]),
+			}
+		}
+
+		if !changed && iter > 0 {
+			break
+		}
+	}
+
+	// For each cluster, pick the actual cube colour closest to its centroid.
+	// A cluster can end up empty (e.g. a seed centroid captures no points);
+	// rather than silently returning fewer than n colours, backfill each
+	// empty cluster with the unused candidate farthest from the
+	// representatives chosen so far, keeping the result maximally spread.
+	labByIdx := make(map[int][3]float64, len(candidates))
+	for i, idx := range candidates {
+		labByIdx[idx] = labs[i]
+	}
+
+	used := make(map[int]bool, n)
+	result := make([]int, 0, n)
+	empty := 0
+
+	for k := 0; k < n; k++ {
+		best, bestDist := -1, 0.0
+		for i, idx := range candidates {
+			if assignments[i] != k {
+				continue
+			}
+			if d := labDistSq(labs[i], centroids[k]); best == -1 || d < bestDist {
+				best, bestDist = idx, d
+			}
+		}
+		if best == -1 {
+			empty++
+			continue
+		}
+		used[best] = true
+		result = append(result, best)
+	}
+
+	for ; empty > 0; empty-- {
+		best, bestDist := -1, -1.0
+		for i, idx := range candidates {
+			if used[idx] {
+				continue
+			}
+			nearest := math.Inf(1)
+			for _, r := range result {
+				if d := labDistSq(labs[i], labByIdx[r]); d < nearest {
+					nearest = d
+				}
+			}
+			if nearest > bestDist {
+				best, bestDist = idx, nearest
+			}
+		}
+		if best == -1 {
+			break
+		}
+		used[best] = true
+		result = append(result, best)
+	}
+
+	return result
+}
+
+func labDistSq(a, b [3]float64) float64 {
+	dl, da, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dl*dl + da*da + db*db
+}